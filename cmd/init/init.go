@@ -0,0 +1,62 @@
+package init
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/buildsafedev/bsf/cmd/styles"
+	"github.com/buildsafedev/bsf/pkg/hcl2nix"
+	"github.com/buildsafedev/bsf/pkg/hcl2nix/migrate"
+	"github.com/buildsafedev/bsf/pkg/langdetect"
+)
+
+// InitCmd detects the current project's language/package manager and seeds bsf.hcl with the
+// matching hcl2nix block
+var InitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Initializes a bsf.hcl for the current project",
+	Long: `
+	bsf init
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		projectType, details, err := langdetect.FindProjectType()
+		if err != nil {
+			fmt.Println(styles.ErrorStyle.Render("error: ", err.Error()))
+			os.Exit(1)
+		}
+
+		config := hcl2nix.Config{BSFVersion: migrate.CurrentVersion}
+		seedConfig(&config, projectType, details)
+
+		f, err := os.Create("bsf.hcl")
+		if err != nil {
+			fmt.Println(styles.ErrorStyle.Render("error: ", err.Error()))
+			os.Exit(1)
+		}
+		defer f.Close()
+
+		if err := hcl2nix.WriteConfig(config, f); err != nil {
+			fmt.Println(styles.ErrorStyle.Render("error: ", err.Error()))
+			os.Exit(1)
+		}
+		fmt.Println(styles.SucessStyle.Render(fmt.Sprintf("bsf.hcl initialized for %s project", projectType)))
+	},
+}
+
+// seedConfig populates config with the hcl2nix block matching projectType, using details
+// gathered by langdetect.FindProjectType. It is a no-op for Unknown, leaving config as a bare
+// bsf.hcl for the user to fill in by hand.
+func seedConfig(config *hcl2nix.Config, projectType langdetect.ProjectType, details *langdetect.ProjectDetails) {
+	switch projectType {
+	case langdetect.GoModule:
+		config.GoModule = &hcl2nix.GoModule{Name: details.Name}
+	case langdetect.PoetryApp:
+		config.PoetryApp = &hcl2nix.PoetryApp{Name: details.Name}
+	case langdetect.RustApp:
+		config.RustApp = &hcl2nix.RustApp{CrateName: details.Name}
+	case langdetect.JsNpmApp:
+		config.JsNpmApp = &hcl2nix.JsNpmApp{Name: details.Name, Entrypoint: details.Entrypoint}
+	}
+}
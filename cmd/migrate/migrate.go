@@ -0,0 +1,56 @@
+package migrate
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/buildsafedev/bsf/cmd/styles"
+	hmigrate "github.com/buildsafedev/bsf/pkg/hcl2nix/migrate"
+)
+
+var dryRun bool
+
+func init() {
+	MigrateCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the migration diff instead of writing bsf.hcl")
+}
+
+// MigrateCmd upgrades bsf.hcl to the current bsf_version
+var MigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Migrates bsf.hcl to the current config schema version",
+	Long: `
+	bsf migrate
+	bsf migrate --dry-run
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		src, err := os.ReadFile("bsf.hcl")
+		if err != nil {
+			fmt.Println(styles.ErrorStyle.Render("error: ", err.Error()))
+			os.Exit(1)
+		}
+
+		migrated, changed, err := hmigrate.Migrate(src, "bsf.hcl")
+		if err != nil {
+			fmt.Println(styles.ErrorStyle.Render("error: ", err.Error()))
+			os.Exit(1)
+		}
+
+		if !changed {
+			fmt.Println(styles.SucessStyle.Render(fmt.Sprintf("bsf.hcl is already at bsf_version %s", hmigrate.CurrentVersion)))
+			return
+		}
+
+		if dryRun {
+			fmt.Print(hmigrate.Diff(src, migrated))
+			return
+		}
+
+		if err := os.WriteFile("bsf.hcl", migrated, 0644); err != nil {
+			fmt.Println(styles.ErrorStyle.Render("error: ", err.Error()))
+			os.Exit(1)
+		}
+		fmt.Println(styles.SucessStyle.Render(fmt.Sprintf("bsf.hcl migrated to bsf_version %s", hmigrate.CurrentVersion)))
+	},
+}
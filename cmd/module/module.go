@@ -0,0 +1,65 @@
+package module
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/buildsafedev/bsf/cmd/styles"
+	"github.com/buildsafedev/bsf/pkg/oci/artifact"
+)
+
+func init() {
+	ModuleCmd.AddCommand(pushCmd)
+	ModuleCmd.AddCommand(pullCmd)
+}
+
+// ModuleCmd groups commands that share bsf's generated flakes and bsf.lock through a registry
+var ModuleCmd = &cobra.Command{
+	Use:   "module",
+	Short: "Push and pull bsf module artifacts (generated flakes + bsf.lock) to/from an OCI registry",
+}
+
+var pushCmd = &cobra.Command{
+	Use:   "push <ref>",
+	Short: "Pushes bsf/ and bsf.lock as an OCI artifact, tagged by content digest",
+	Long: `
+	bsf module push <registry>/<repo>
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) < 1 {
+			fmt.Println(styles.HintStyle.Render("hint:", "run `bsf module push <ref>` to push bsf/ and bsf.lock to a registry"))
+			os.Exit(1)
+		}
+
+		fmt.Println(styles.HighlightStyle.Render("Pushing bsf module artifact..."))
+		pushedRef, err := artifact.Push(".", args[0])
+		if err != nil {
+			fmt.Println(styles.ErrorStyle.Render("error: ", err.Error()))
+			os.Exit(1)
+		}
+		fmt.Println(styles.SucessStyle.Render(fmt.Sprintf("bsf module pushed to %s", pushedRef)))
+	},
+}
+
+var pullCmd = &cobra.Command{
+	Use:   "pull <ref>",
+	Short: "Pulls a bsf module artifact and verifies it against its content digest",
+	Long: `
+	bsf module pull <registry>/<repo>:sha256-<digest>
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) < 1 {
+			fmt.Println(styles.HintStyle.Render("hint:", "run `bsf module pull <ref>` to fetch bsf/ and bsf.lock from a registry"))
+			os.Exit(1)
+		}
+
+		fmt.Println(styles.HighlightStyle.Render("Pulling bsf module artifact..."))
+		if err := artifact.Pull(args[0], "."); err != nil {
+			fmt.Println(styles.ErrorStyle.Render("error: ", err.Error()))
+			os.Exit(1)
+		}
+		fmt.Println(styles.SucessStyle.Render(fmt.Sprintf("bsf module pulled from %s", args[0])))
+	},
+}
@@ -21,15 +21,16 @@ import (
 )
 
 var (
-	platform, output, tag, path                   string
-	push, loadDocker, loadPodman, devDeps, dfSwap bool
+	platform, output, tag, path, sign, signKeyRef             string
+	push, loadDocker, loadPodman, devDeps, dfSwap, buildIndex bool
+	attestSBOM                                                bool
 )
 var (
 	supportedPlatforms = []string{"linux/amd64", "linux/arm64"}
 )
 
 func init() {
-	OCICmd.Flags().StringVarP(&platform, "platform", "p", "", "The platform to build the image for")
+	OCICmd.Flags().StringVarP(&platform, "platform", "p", "", "The platform to build the image for. Accepts a comma-separated list (e.g. linux/amd64,linux/arm64) with --index")
 	OCICmd.Flags().StringVarP(&output, "output", "o", "", "location of the build artifacts generated")
 	OCICmd.Flags().BoolVarP(&loadDocker, "load-docker", "", false, "Load the image into docker daemon")
 	OCICmd.Flags().BoolVarP(&loadPodman, "load-podman", "", false, "Load the image into podman")
@@ -38,6 +39,10 @@ func init() {
 	OCICmd.Flags().BoolVarP(&dfSwap, "df-swap", "", false, "Modify base images in Dockerfile")
 	OCICmd.Flags().StringVarP(&tag, "tag", "t", "", "The tag that will be replaced with original tag in Dockerfile")
 	OCICmd.Flags().StringVar(&path, "path", "", "The path to Dockerfile")
+	OCICmd.Flags().BoolVarP(&buildIndex, "index", "", false, "Build an OCI image index (manifest list) across every --platform given and push it as a single tag (--load-docker/--load-podman are rejected)")
+	OCICmd.Flags().StringVar(&sign, "sign", "", "Sign the built image with cosign: cosign-keyless or cosign-keyref (use --sign-key-ref with cosign-keyref)")
+	OCICmd.Flags().StringVar(&signKeyRef, "sign-key-ref", "", "cosign key reference to sign with (file path or KMS URI), required for --sign cosign-keyref")
+	OCICmd.Flags().BoolVar(&attestSBOM, "attest-sbom", false, "Attach the generated SBOM to the image as a cosign in-toto attestation")
 }
 
 // OCICmd represents the export command
@@ -62,6 +67,14 @@ var OCICmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		if buildIndex {
+			if err := runIndexBuild(conf, args[0]); err != nil {
+				fmt.Println(styles.ErrorStyle.Render("error: ", err.Error()))
+				os.Exit(1)
+			}
+			return
+		}
+
 		artifact, p, err := ProcessPlatformAndConfig(conf, platform, args[0])
 		if err != nil {
 			fmt.Println(styles.ErrorStyle.Render("error: ", err.Error()))
@@ -218,6 +231,28 @@ var OCICmd = &cobra.Command{
 				os.Exit(1)
 			}
 			fmt.Println(styles.SucessStyle.Render(fmt.Sprintf("Image %s pushed to registry", artifact.Name)))
+
+			if sign != "" {
+				fmt.Println(styles.HighlightStyle.Render("Signing image with cosign..."))
+				if err := oci.SignImage(output+"/result", artifact.Name, oci.SignProvider(sign), signKeyRef); err != nil {
+					fmt.Println(styles.ErrorStyle.Render("error:", err.Error()))
+					os.Exit(1)
+				}
+				fmt.Println(styles.SucessStyle.Render(fmt.Sprintf("Image %s signed", artifact.Name)))
+			}
+
+			if attestSBOM {
+				fmt.Println(styles.HighlightStyle.Render("Attaching SBOM attestation..."))
+				sbomPath := output + "/sbom.json"
+				if err := oci.AttachSBOMAttestation(output+"/result", artifact.Name, sbomPath, ""); err != nil {
+					fmt.Println(styles.ErrorStyle.Render("error:", err.Error()))
+					os.Exit(1)
+				}
+				fmt.Println(styles.SucessStyle.Render(fmt.Sprintf("SBOM attestation attached to %s", artifact.Name)))
+			}
+		} else if sign != "" || attestSBOM {
+			fmt.Println(styles.ErrorStyle.Render("error:", "--sign/--attest-sbom require --push: cosign needs the manifest uploaded to the registry first"))
+			os.Exit(1)
 		}
 	},
 }
@@ -229,39 +264,154 @@ func ProcessPlatformAndConfig(conf *hcl2nix.Config, plat string, envName string)
 		plat = tos + "/" + tarch
 	}
 
-	pfound := false
+	if err := validatePlatform(plat); err != nil {
+		return hcl2nix.OCIArtifact{}, "", err
+	}
+
+	artifact, err := findOCIArtifact(conf, envName)
+	if err != nil {
+		return hcl2nix.OCIArtifact{}, "", err
+	}
+
+	return artifact, plat, nil
+}
+
+// ProcessPlatformsAndConfig is the --index counterpart of ProcessPlatformAndConfig: it
+// validates every comma-separated platform in plat instead of just one
+func ProcessPlatformsAndConfig(conf *hcl2nix.Config, plat string, envName string) (hcl2nix.OCIArtifact, []string, error) {
+	if plat == "" {
+		return hcl2nix.OCIArtifact{}, nil, fmt.Errorf("error: --index requires --platform to list the platforms to build, e.g. --platform linux/amd64,linux/arm64")
+	}
+
+	plats := strings.Split(plat, ",")
+	for i := range plats {
+		plats[i] = strings.TrimSpace(plats[i])
+		if err := validatePlatform(plats[i]); err != nil {
+			return hcl2nix.OCIArtifact{}, nil, err
+		}
+	}
+
+	artifact, err := findOCIArtifact(conf, envName)
+	if err != nil {
+		return hcl2nix.OCIArtifact{}, nil, err
+	}
+
+	return artifact, plats, nil
+}
+
+// validatePlatform checks plat against supportedPlatforms
+func validatePlatform(plat string) error {
 	for _, sp := range supportedPlatforms {
 		if strings.Contains(plat, sp) {
-			pfound = true
-			break
+			return nil
 		}
 	}
-	if !pfound {
-		return hcl2nix.OCIArtifact{}, "", fmt.Errorf("Platform %s is not supported. Supported platforms are %s", platform, strings.Join(supportedPlatforms, ", "))
-	}
+	return fmt.Errorf("Platform %s is not supported. Supported platforms are %s", plat, strings.Join(supportedPlatforms, ", "))
+}
 
+// findOCIArtifact validates every oci block in conf and returns the one matching envName
+func findOCIArtifact(conf *hcl2nix.Config, envName string) (hcl2nix.OCIArtifact, error) {
 	envNames := make([]string, 0, len(conf.OCIArtifact))
-	var found bool
-	artifact := hcl2nix.OCIArtifact{}
 	for _, ec := range conf.OCIArtifact {
 		errStr := ec.Validate(conf)
 		if errStr != nil {
-			return hcl2nix.OCIArtifact{}, "", fmt.Errorf("Config for export block %s is invalid\n Error: %s", ec.Name, *errStr)
+			return hcl2nix.OCIArtifact{}, fmt.Errorf("Config for export block %s is invalid\n Error: %s", ec.Name, *errStr)
 		}
 
 		if ec.Artifact == envName {
-			found = true
-			artifact = ec
-			break
+			return ec, nil
 		}
 		envNames = append(envNames, ec.Artifact)
 	}
 
-	if !found {
-		return hcl2nix.OCIArtifact{}, "", fmt.Errorf("error: No such environment found. Valid oci environment that can be built are: %s", strings.Join(envNames, ", "))
+	return hcl2nix.OCIArtifact{}, fmt.Errorf("error: No such environment found. Valid oci environment that can be built are: %s", strings.Join(envNames, ", "))
+}
+
+// runIndexBuild builds one image per platform in --platform, assembles them into an OCI
+// image index and, with --push, pushes that index as a single tag. The index is not loaded into
+// docker or podman: --load-docker/--load-podman return an error.
+func runIndexBuild(conf *hcl2nix.Config, envName string) error {
+	artifact, plats, err := ProcessPlatformsAndConfig(conf, platform, envName)
+	if err != nil {
+		return err
 	}
 
-	return artifact, plat, nil
+	sc, fh, err := binit.GetBSFInitializers()
+	if err != nil {
+		return err
+	}
+	if err := generate.Generate(fh, sc); err != nil {
+		return err
+	}
+
+	if output == "" {
+		output = "bsf-result"
+	}
+
+	if err := bgit.Add("bsf/"); err != nil {
+		return err
+	}
+	if err := bgit.Ignore(output + "/"); err != nil {
+		return err
+	}
+
+	builds := make([]oci.ArchBuild, 0, len(plats))
+	for _, plat := range plats {
+		tos, tarch := platformutils.FindPlatform(plat)
+		archOutput := fmt.Sprintf("%s/%s-%s", output, tos, tarch)
+		symlink := "/result"
+
+		fmt.Println(styles.HighlightStyle.Render(fmt.Sprintf("Building %s...", plat)))
+		if err := nixcmd.Build(archOutput+symlink, genOCIAttrName(artifact.Artifact, plat, artifact)); err != nil {
+			return fmt.Errorf("error: building %s: %s", plat, err.Error())
+		}
+
+		lockData, err := os.ReadFile("bsf.lock")
+		if err != nil {
+			return err
+		}
+		lockFile := &hcl2nix.LockFile{}
+		if err := json.Unmarshal(lockData, lockFile); err != nil {
+			return err
+		}
+
+		appDetails, graph, err := nixcmd.GetRuntimeClosureGraph(lockFile.App.Name, archOutput, symlink)
+		if err != nil {
+			return err
+		}
+		appDetails.Name = artifact.Name
+
+		if err := build.GenerateArtifcats(archOutput, symlink, lockFile, appDetails, graph, tos, tarch); err != nil {
+			return err
+		}
+
+		builds = append(builds, oci.ArchBuild{OS: tos, Architecture: tarch, ResultDir: archOutput + symlink})
+	}
+
+	index, err := oci.BuildIndex(builds)
+	if err != nil {
+		return err
+	}
+
+	indexPath, err := oci.WriteIndex(index, output)
+	if err != nil {
+		return err
+	}
+	fmt.Println(styles.SucessStyle.Render(fmt.Sprintf("Built image index for %s, please check the %s directory", strings.Join(plats, ", "), output)))
+
+	if push {
+		fmt.Println(styles.HighlightStyle.Render("Pushing image index to registry..."))
+		if err := oci.PushIndex(builds, indexPath, artifact.Name); err != nil {
+			return err
+		}
+		fmt.Println(styles.SucessStyle.Render(fmt.Sprintf("Image index %s pushed to registry", artifact.Name)))
+	}
+
+	if loadDocker || loadPodman {
+		return fmt.Errorf("error: --load-docker/--load-podman only support a single platform; use --push for multi-arch --index builds")
+	}
+
+	return nil
 }
 
 func modifyDockerfileWithTag(path, tag string, devDeps bool) error {
@@ -7,6 +7,7 @@ import (
 	"github.com/buildsafedev/bsf/cmd/search"
 	"github.com/buildsafedev/bsf/cmd/styles"
 	"github.com/buildsafedev/bsf/pkg/vulnerability"
+	"github.com/buildsafedev/bsf/pkg/vulnerability/policy"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
@@ -19,13 +20,23 @@ type vulnListModel struct {
 	vulnTable table.Model
 }
 
-func convVulns2Rows(vulnerabilities *bsfv1.FetchVulnerabilitiesResponse) []table.Row {
+func convVulns2Rows(vulnerabilities *bsfv1.FetchVulnerabilitiesResponse, verdict policy.Verdict) []table.Row {
 	items := make([]table.Row, 0, len(vulnerabilities.Vulnerabilities))
 
+	violated := make(map[string]bool, len(verdict.Violations))
+	for _, v := range verdict.Violations {
+		violated[v.CVE] = true
+	}
+
 	sortedVulns := vulnerability.SortVulnerabilities(vulnerabilities.Vulnerabilities)
 	for _, v := range sortedVulns {
+		cve := v.Id
+		if violated[v.Id] {
+			cve = styles.ErrorStyle.Render(cve)
+		}
+
 		items = append(items, table.Row{
-			v.Id,
+			cve,
 			v.Severity,
 			fmt.Sprint(v.Cvss[0].Metrics.BaseScore),
 			vulnerability.DeriveAV(v.Cvss[0].Vector),
@@ -34,7 +45,9 @@ func convVulns2Rows(vulnerabilities *bsfv1.FetchVulnerabilitiesResponse) []table
 	return items
 }
 
-func initVulnTable(vulnResp *bsfv1.FetchVulnerabilitiesResponse) *vulnListModel {
+// initVulnTable renders the vulnerability table. verdict marks non-policy-compliant rows; pass
+// the zero value of policy.Verdict when no vulnpolicy block is configured.
+func initVulnTable(vulnResp *bsfv1.FetchVulnerabilitiesResponse, verdict policy.Verdict) *vulnListModel {
 
 	frameHeight, frameWidth := styles.DocStyle.GetFrameSize()
 
@@ -46,7 +59,7 @@ func initVulnTable(vulnResp *bsfv1.FetchVulnerabilitiesResponse) *vulnListModel
 		{Title: "Vector", Width: frameWidth},
 	}
 
-	rows := convVulns2Rows(vulnResp)
+	rows := convVulns2Rows(vulnResp, verdict)
 	t := table.New(
 		table.WithColumns(columns),
 		table.WithRows(rows),
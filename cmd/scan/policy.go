@@ -0,0 +1,42 @@
+package scan
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/buildsafedev/bsf/cmd/styles"
+	"github.com/buildsafedev/bsf/pkg/vulnerability/policy"
+
+	bsfv1 "github.com/buildsafedev/bsf-apis/go/buildsafe/v1"
+)
+
+// RunPolicyScan evaluates vulnResp against pol - the vulnpolicy block already decoded off
+// bsf.hcl's Config - and prints the verdict in format ("", "json" or "sarif"). When exitCode is
+// true, the process exits non-zero if any vulnerability violates policy - suitable for CI gates
+// (bsf scan --policy --exit-code). pol may be nil when bsf.hcl has no vulnpolicy block.
+func RunPolicyScan(pol *policy.Policy, vulnResp *bsfv1.FetchVulnerabilitiesResponse, format string, exitCode bool) error {
+	verdict := policy.Evaluate(pol, vulnResp)
+
+	var out []byte
+	var err error
+	switch format {
+	case "sarif":
+		out, err = policy.ToSARIF(verdict)
+	default:
+		out, err = policy.ToJSON(verdict)
+	}
+	if err != nil {
+		return fmt.Errorf("error: rendering policy verdict: %s", err.Error())
+	}
+	fmt.Println(string(out))
+
+	if !verdict.Compliant {
+		if exitCode {
+			fmt.Println(styles.ErrorStyle.Render("error:", "vulnerability policy violated"))
+			os.Exit(1)
+		}
+		fmt.Println(styles.HintStyle.Render("hint:", "vulnerability policy violated, re-run with --exit-code in CI to fail the build"))
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package scan
+
+import (
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"github.com/buildsafedev/bsf/cmd/styles"
+	"github.com/buildsafedev/bsf/pkg/hcl2nix"
+	"github.com/buildsafedev/bsf/pkg/vulnerability"
+	"github.com/buildsafedev/bsf/pkg/vulnerability/policy"
+)
+
+var (
+	policyMode bool
+	exitCode   bool
+	format     string
+)
+
+func init() {
+	ScanCmd.Flags().BoolVar(&policyMode, "policy", false, "Evaluate the scan against the vulnpolicy block in bsf.hcl and print a verdict instead of opening the interactive table")
+	ScanCmd.Flags().BoolVar(&exitCode, "exit-code", false, "With --policy, exit non-zero if any vulnerability violates policy; for CI gates")
+	ScanCmd.Flags().StringVar(&format, "format", "json", "Verdict format for --policy: json or sarif")
+}
+
+// ScanCmd scans a built artifact for vulnerabilities. Run with no flags it opens the interactive
+// table, marking rows that violate the vulnpolicy block in bsf.hcl, if any is configured. With
+// --policy it skips the table and prints a machine-readable verdict, exiting non-zero on
+// violation when --exit-code is also set - suitable for CI.
+var ScanCmd = &cobra.Command{
+	Use:   "scan <ref>",
+	Short: "Scans an artifact for vulnerabilities, optionally gated by the vulnpolicy in bsf.hcl",
+	Long: `
+	bsf scan <ref>
+	bsf scan <ref> --policy --exit-code
+	`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) < 1 {
+			fmt.Println(styles.HintStyle.Render("hint:", "run `bsf scan <ref>` to scan an artifact for vulnerabilities"))
+			os.Exit(1)
+		}
+
+		vulnResp, err := vulnerability.Fetch(args[0])
+		if err != nil {
+			fmt.Println(styles.ErrorStyle.Render("error: ", err.Error()))
+			os.Exit(1)
+		}
+
+		conf, err := hcl2nix.ReadHclFile("bsf.hcl")
+		if err != nil {
+			fmt.Println(styles.ErrorStyle.Render("error: ", err.Error()))
+			os.Exit(1)
+		}
+
+		if policyMode {
+			if err := RunPolicyScan(conf.Vulnpolicy, vulnResp, format, exitCode); err != nil {
+				fmt.Println(styles.ErrorStyle.Render("error: ", err.Error()))
+				os.Exit(1)
+			}
+			return
+		}
+
+		verdict := policy.Evaluate(conf.Vulnpolicy, vulnResp)
+		if _, err := tea.NewProgram(initVulnTable(vulnResp, verdict)).Run(); err != nil {
+			fmt.Println(styles.ErrorStyle.Render("error: ", err.Error()))
+			os.Exit(1)
+		}
+	},
+}
@@ -11,12 +11,15 @@ import (
 	"github.com/hashicorp/hcl/v2/hclparse"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 
+	"github.com/buildsafedev/bsf/pkg/hcl2nix/migrate"
 	bstrings "github.com/buildsafedev/bsf/pkg/strings"
 	"github.com/buildsafedev/bsf/pkg/update"
+	"github.com/buildsafedev/bsf/pkg/vulnerability/policy"
 )
 
 // Config for hcl2nix
 type Config struct {
+	BSFVersion     string          `hcl:"bsf_version"`
 	Packages       Packages        `hcl:"packages,block"`
 	GoModule       *GoModule       `hcl:"gomodule,block"`
 	PoetryApp      *PoetryApp      `hcl:"poetryapp,block"`
@@ -25,6 +28,7 @@ type Config struct {
 	OCIArtifact    []OCIArtifact   `hcl:"oci,block"`
 	ConfigFiles    []ConfigFiles   `hcl:"config,block"`
 	GitHubReleases []GitHubRelease `hcl:"githubRelease,block"`
+	Vulnpolicy     *policy.Policy  `hcl:"vulnpolicy,block"`
 }
 
 // GitHubRelease holds github release parameters
@@ -59,6 +63,8 @@ func ReadHclFile(fileName string) (*Config, error) {
 
 // WriteConfig writes packages to writer
 func WriteConfig(config Config, wr io.Writer) error {
+	config.BSFVersion = migrate.CurrentVersion
+
 	f := hclwrite.NewEmptyFile()
 	gohcl.EncodeIntoBody(&config, f.Body())
 	_, err := f.WriteTo(wr)
@@ -95,41 +101,52 @@ func ModifyConfig(oldName string, artifact OCIArtifact, config *Config) error {
 	return nil
 }
 
-// ReadConfig reads config from bytes and returns Config. If any errors are encountered, they are written to dstErr
+// ReadConfig reads config from bytes and returns Config. If any errors are encountered, they are written to dstErr.
+// Configs written against an older bsf_version (or none at all) are migrated to migrate.CurrentVersion first.
 func ReadConfig(src []byte, dstErr io.Writer) (*Config, error) {
 	parser := hclparse.NewParser()
 	f, diags := parser.ParseHCL(src, "bsf.hcl")
 	if diags.HasErrors() {
-		wr := hcl.NewDiagnosticTextWriter(
-			dstErr,
-			parser.Files(),
-			78,
-			true,
-		)
-		if err := wr.WriteDiagnostics(diags); err != nil {
-			return nil, fmt.Errorf("error writing diagnostics: %w", err)
+		return nil, writeDiagnostics(dstErr, parser, diags)
+	}
+
+	migrated, changed, err := migrate.Migrate(src, "bsf.hcl")
+	if err != nil {
+		return nil, fmt.Errorf("error: migrating bsf.hcl: %s", err.Error())
+	}
+
+	if changed {
+		parser = hclparse.NewParser()
+		f, diags = parser.ParseHCL(migrated, "bsf.hcl")
+		if diags.HasErrors() {
+			return nil, writeDiagnostics(dstErr, parser, diags)
 		}
-		return nil, diags
 	}
 
 	var config Config
 	diags = gohcl.DecodeBody(f.Body, nil, &config)
 	if diags.HasErrors() {
-		wr := hcl.NewDiagnosticTextWriter(
-			dstErr,
-			parser.Files(),
-			78,
-			true,
-		)
-		if err := wr.WriteDiagnostics(diags); err != nil {
-			return nil, fmt.Errorf("error writing diagnostics: %w", err)
-		}
-		return nil, diags
+		return nil, writeDiagnostics(dstErr, parser, diags)
 	}
 
 	return &config, nil
 }
 
+// writeDiagnostics renders diags as wrapped, source-quoting text to dstErr and returns diags as
+// the error to propagate
+func writeDiagnostics(dstErr io.Writer, parser *hclparse.Parser, diags hcl.Diagnostics) error {
+	wr := hcl.NewDiagnosticTextWriter(
+		dstErr,
+		parser.Files(),
+		78,
+		true,
+	)
+	if err := wr.WriteDiagnostics(diags); err != nil {
+		return fmt.Errorf("error writing diagnostics: %w", err)
+	}
+	return diags
+}
+
 // AddPackages updates config with new packages. It appends new packages to existing packages
 func AddPackages(src []byte, packages Packages, wr io.Writer) error {
 	existingConfig, err := ReadConfig(src, io.Discard)
@@ -0,0 +1,156 @@
+// Package migrate rewrites bsf.hcl files written against an older bsf_version to the
+// current schema, so that field renames and additions in hcl2nix.Config don't silently
+// corrupt existing user configs.
+package migrate
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// CurrentVersion is the bsf_version every config is migrated to
+const CurrentVersion = "2"
+
+// unversioned is the implicit version of any bsf.hcl predating the bsf_version attribute
+const unversioned = ""
+
+// Migration rewrites a config's AST from one version to the next
+type Migration struct {
+	From  string
+	To    string
+	Apply func(body *hclwrite.Body) error
+}
+
+// migrations is the registered chain of migrators, applied in order until CurrentVersion is reached
+var migrations = []Migration{
+	{From: unversioned, To: "2", Apply: migrateUnversionedToV2},
+}
+
+// migrateUnversionedToV2 stamps the bsf_version attribute introduced in v2; v1 configs carried
+// no other schema changes
+func migrateUnversionedToV2(body *hclwrite.Body) error {
+	body.SetAttributeValue("bsf_version", cty.StringVal("2"))
+	return nil
+}
+
+// Version reads the bsf_version attribute out of src, returning unversioned if it is absent
+func Version(src []byte, fileName string) (string, error) {
+	parser := hclparse.NewParser()
+	f, diags := parser.ParseHCL(src, fileName)
+	if diags.HasErrors() {
+		return "", diags
+	}
+
+	schema := &hcl.BodySchema{Attributes: []hcl.AttributeSchema{{Name: "bsf_version"}}}
+	content, _, diags := f.Body.PartialContent(schema)
+	if diags.HasErrors() {
+		return "", diags
+	}
+
+	attr, ok := content.Attributes["bsf_version"]
+	if !ok {
+		return unversioned, nil
+	}
+
+	val, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() {
+		return "", diags
+	}
+
+	if val.Type() != cty.String {
+		return "", fmt.Errorf("error: bsf_version must be a string, got %s", val.Type().FriendlyName())
+	}
+
+	return val.AsString(), nil
+}
+
+// Migrate rewrites src to CurrentVersion, applying every registered migration along the way.
+// It returns the migrated bytes and whether anything changed; src is returned unmodified if it
+// is already at CurrentVersion.
+func Migrate(src []byte, fileName string) ([]byte, bool, error) {
+	version, err := Version(src, fileName)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if version == CurrentVersion {
+		return src, false, nil
+	}
+
+	f, diags := hclwrite.ParseConfig(src, fileName, hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return nil, false, diags
+	}
+
+	for version != CurrentVersion {
+		migration, ok := migrationFrom(version)
+		if !ok {
+			return nil, false, fmt.Errorf("error: no migration registered from bsf_version %q to %s", version, CurrentVersion)
+		}
+
+		if err := migration.Apply(f.Body()); err != nil {
+			return nil, false, fmt.Errorf("error: migrating from bsf_version %q to %s: %s", version, migration.To, err.Error())
+		}
+		version = migration.To
+	}
+
+	return f.Bytes(), true, nil
+}
+
+// migrationFrom looks up the registered migration starting at version
+func migrationFrom(version string) (Migration, bool) {
+	for _, m := range migrations {
+		if m.From == version {
+			return m, true
+		}
+	}
+	return Migration{}, false
+}
+
+// Diff renders a simple line-oriented diff between original and migrated, for bsf migrate --dry-run
+func Diff(original, migrated []byte) string {
+	origLines := splitLines(original)
+	newLines := splitLines(migrated)
+
+	origSet := make(map[string]bool, len(origLines))
+	for _, l := range origLines {
+		origSet[l] = true
+	}
+	newSet := make(map[string]bool, len(newLines))
+	for _, l := range newLines {
+		newSet[l] = true
+	}
+
+	var diff string
+	for _, l := range origLines {
+		if !newSet[l] {
+			diff += "- " + l + "\n"
+		}
+	}
+	for _, l := range newLines {
+		if !origSet[l] {
+			diff += "+ " + l + "\n"
+		}
+	}
+
+	return diff
+}
+
+func splitLines(b []byte) []string {
+	var lines []string
+	start := 0
+	for i, c := range b {
+		if c == '\n' {
+			lines = append(lines, string(b[start:i]))
+			start = i + 1
+		}
+	}
+	if start < len(b) {
+		lines = append(lines, string(b[start:]))
+	}
+	return lines
+}
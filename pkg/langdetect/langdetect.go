@@ -1,9 +1,12 @@
 package langdetect
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/BurntSushi/toml"
 )
 
 type (
@@ -14,6 +17,12 @@ type (
 const (
 	// GoModule is the project type for Go modules
 	GoModule ProjectType = "GoModule"
+	// PoetryApp is the project type for Poetry-managed Python projects
+	PoetryApp ProjectType = "PoetryApp"
+	// RustApp is the project type for Cargo-managed Rust projects
+	RustApp ProjectType = "RustApp"
+	// JsNpmApp is the project type for npm-managed JS/TS projects
+	JsNpmApp ProjectType = "JsNpmApp"
 	// Unknown is the project type for unknown project types
 	Unknown ProjectType = "Unknown"
 )
@@ -58,8 +67,75 @@ func FindProjectType() (ProjectType, *ProjectDetails, error) {
 			return GoModule, &ProjectDetails{
 				Name: moduleName,
 			}, nil
+
+		case "pyproject.toml":
+			f, err := os.ReadFile(file)
+			if err != nil {
+				return "", nil, err
+			}
+
+			var parsed struct {
+				Tool struct {
+					Poetry struct {
+						Name string `toml:"name"`
+					} `toml:"poetry"`
+				} `toml:"tool"`
+				Project struct {
+					Name string `toml:"name"`
+				} `toml:"project"`
+			}
+			if _, err := toml.Decode(string(f), &parsed); err != nil {
+				return "", nil, err
+			}
+
+			name := parsed.Tool.Poetry.Name
+			if name == "" {
+				name = parsed.Project.Name
+			}
+
+			return PoetryApp, &ProjectDetails{
+				Name: name,
+			}, nil
+
+		case "Cargo.toml":
+			f, err := os.ReadFile(file)
+			if err != nil {
+				return "", nil, err
+			}
+
+			var parsed struct {
+				Package struct {
+					Name string `toml:"name"`
+				} `toml:"package"`
+			}
+			if _, err := toml.Decode(string(f), &parsed); err != nil {
+				return "", nil, err
+			}
+
+			return RustApp, &ProjectDetails{
+				Name: parsed.Package.Name,
+			}, nil
+
+		case "package.json":
+			f, err := os.ReadFile(file)
+			if err != nil {
+				return "", nil, err
+			}
+
+			var parsed struct {
+				Name string `json:"name"`
+				Main string `json:"main"`
+			}
+			if err := json.Unmarshal(f, &parsed); err != nil {
+				return "", nil, err
+			}
+
+			return JsNpmApp, &ProjectDetails{
+				Name:       parsed.Name,
+				Entrypoint: parsed.Main,
+			}, nil
 		}
 	}
 
 	return Unknown, nil, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,245 @@
+// Package artifact pushes and pulls bsf's generated flakes and bsf.lock as a content-addressable
+// OCI artifact, so teams can share reproducible build inputs through any OCI registry instead of Git.
+package artifact
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// MediaType is the artifact type bsf tags its module archives with
+const MediaType = "application/vnd.bsf.module.v1+tar+gzip"
+
+// moduleFiles are the paths pushed as part of a bsf module artifact
+var moduleFiles = []string{"bsf", "bsf.lock"}
+
+// Push archives bsf/ and bsf.lock from dir and pushes them to ref, tagged by the archive's
+// sha256 content digest so the same inputs always resolve to the same tag. It returns the
+// digest-qualified ref the artifact was actually pushed to, since ref's own tag, if any, is
+// replaced.
+func Push(dir, ref string) (string, error) {
+	archivePath, digest, err := buildArchive(dir)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(archivePath)
+
+	pushedRef := taggedRef(ref, digest)
+	cmd := exec.Command("oras", "push", pushedRef, "--artifact-type", MediaType, archivePath+":"+MediaType)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("error: oras push failed: %s: %s", err.Error(), strings.TrimSpace(string(out)))
+	}
+
+	return pushedRef, nil
+}
+
+// Pull fetches the module artifact at ref and extracts bsf/ and bsf.lock into destDir, verifying
+// the archive's content digest against the tag when ref is of the form repo:sha256-<digest>.
+func Pull(ref, destDir string) error {
+	tmpDir, err := os.MkdirTemp("", "bsf-module-*")
+	if err != nil {
+		return fmt.Errorf("error: %s", err.Error())
+	}
+	defer os.RemoveAll(tmpDir)
+
+	cmd := exec.Command("oras", "pull", ref, "-o", tmpDir)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("error: oras pull failed: %s: %s", err.Error(), strings.TrimSpace(string(out)))
+	}
+
+	archivePath, err := findArchive(tmpDir)
+	if err != nil {
+		return err
+	}
+
+	if expected, ok := digestFromRef(ref); ok {
+		actual, err := fileDigest(archivePath)
+		if err != nil {
+			return err
+		}
+		if actual != expected {
+			return fmt.Errorf("error: module artifact digest mismatch: expected sha256:%s, got sha256:%s", expected, actual)
+		}
+	}
+
+	return extractArchive(archivePath, destDir)
+}
+
+// buildArchive tars+gzips moduleFiles under dir and returns the archive path and its sha256 digest
+func buildArchive(dir string) (string, string, error) {
+	f, err := os.CreateTemp("", "bsf-module-*.tar.gz")
+	if err != nil {
+		return "", "", fmt.Errorf("error: %s", err.Error())
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	for _, rel := range moduleFiles {
+		path := filepath.Join(dir, rel)
+		if err := addToArchive(tw, dir, path); err != nil {
+			return "", "", fmt.Errorf("error: archiving %s: %s", rel, err.Error())
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return "", "", err
+	}
+	if err := gz.Close(); err != nil {
+		return "", "", err
+	}
+
+	digest, err := fileDigest(f.Name())
+	if err != nil {
+		return "", "", err
+	}
+
+	return f.Name(), digest, nil
+}
+
+// addToArchive walks path (file or directory) and writes it into tw with headers relative to dir
+func addToArchive(tw *tar.Writer, dir, path string) error {
+	return filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = rel
+		// drop mtime and ownership so the digest depends only on paths, modes and contents
+		hdr.ModTime = time.Unix(0, 0)
+		hdr.AccessTime, hdr.ChangeTime = time.Time{}, time.Time{}
+		hdr.Uid, hdr.Gid = 0, 0
+		hdr.Uname, hdr.Gname = "", ""
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		src, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+
+		_, err = io.Copy(tw, src)
+		return err
+	})
+}
+
+// extractArchive unpacks a tar.gz archive into destDir
+func extractArchive(archivePath, destDir string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return fmt.Errorf("error: %s", err.Error())
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("error: %s", err.Error())
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("error: %s", err.Error())
+		}
+
+		target := filepath.Join(destDir, hdr.Name)
+		relTarget, err := filepath.Rel(destDir, target)
+		if err != nil || relTarget == ".." || strings.HasPrefix(relTarget, ".."+string(os.PathSeparator)) {
+			return fmt.Errorf("error: tar entry %q escapes destination directory", hdr.Name)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(hdr.Mode))
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, tr); err != nil {
+			out.Close()
+			return err
+		}
+		out.Close()
+	}
+
+	return nil
+}
+
+// fileDigest returns the sha256 digest of path as a hex string
+func fileDigest(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("error: %s", err.Error())
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// taggedRef rewrites ref to carry the sha256-<digest> tag, replacing any existing tag
+func taggedRef(ref, digest string) string {
+	repo := ref
+	if idx := strings.LastIndex(ref, ":"); idx != -1 && !strings.Contains(ref[idx:], "/") {
+		repo = ref[:idx]
+	}
+	return fmt.Sprintf("%s:sha256-%s", repo, digest)
+}
+
+// digestFromRef extracts the digest from a sha256-<digest> tag, if ref carries one
+func digestFromRef(ref string) (string, bool) {
+	idx := strings.LastIndex(ref, ":sha256-")
+	if idx == -1 {
+		return "", false
+	}
+	return ref[idx+len(":sha256-"):], true
+}
+
+// findArchive returns the path of the single file oras pull wrote into dir
+func findArchive(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("error: %s", err.Error())
+	}
+	for _, e := range entries {
+		if !e.IsDir() {
+			return filepath.Join(dir, e.Name()), nil
+		}
+	}
+	return "", fmt.Errorf("error: oras pull produced no files in %s", dir)
+}
@@ -0,0 +1,130 @@
+package oci
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// indexMediaType is the media type of an OCI image index (manifest list)
+const indexMediaType = "application/vnd.oci.image.index.v1+json"
+
+// manifestMediaType is the media type bsf gives to the per-arch image manifests it references
+const manifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+
+// Platform describes the OS/architecture a manifest was built for
+type Platform struct {
+	OS           string `json:"os"`
+	Architecture string `json:"architecture"`
+}
+
+// ManifestDescriptor references one of the per-arch manifests that make up an image index
+type ManifestDescriptor struct {
+	MediaType string   `json:"mediaType"`
+	Digest    string   `json:"digest"`
+	Size      int64    `json:"size"`
+	Platform  Platform `json:"platform"`
+}
+
+// ImageIndex is the OCI image-index (manifest list) document bsf writes for multi-arch builds
+type ImageIndex struct {
+	SchemaVersion int                  `json:"schemaVersion"`
+	MediaType     string               `json:"mediaType"`
+	Manifests     []ManifestDescriptor `json:"manifests"`
+}
+
+// ArchBuild pairs a per-arch image layout with the platform it was built for
+type ArchBuild struct {
+	OS           string
+	Architecture string
+	ResultDir    string
+}
+
+// BuildIndex assembles an OCI image index referencing each arch's manifest by digest
+func BuildIndex(builds []ArchBuild) (ImageIndex, error) {
+	index := ImageIndex{
+		SchemaVersion: 2,
+		MediaType:     indexMediaType,
+	}
+
+	for _, b := range builds {
+		digest, size, err := manifestDigest(b.ResultDir)
+		if err != nil {
+			return ImageIndex{}, fmt.Errorf("error: computing digest for %s/%s: %s", b.OS, b.Architecture, err.Error())
+		}
+
+		index.Manifests = append(index.Manifests, ManifestDescriptor{
+			MediaType: manifestMediaType,
+			Digest:    digest,
+			Size:      size,
+			Platform: Platform{
+				OS:           b.OS,
+				Architecture: b.Architecture,
+			},
+		})
+	}
+
+	return index, nil
+}
+
+// WriteIndex writes index.json into outputDir, alongside the per-arch result directories
+func WriteIndex(index ImageIndex, outputDir string) (string, error) {
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error: marshalling image index: %s", err.Error())
+	}
+
+	indexPath := filepath.Join(outputDir, "index.json")
+	if err := os.WriteFile(indexPath, data, 0644); err != nil {
+		return "", fmt.Errorf("error: writing image index: %s", err.Error())
+	}
+
+	return indexPath, nil
+}
+
+// manifestDigest computes the sha256 digest and size of the manifest.json found in an OCI layout directory
+func manifestDigest(resultDir string) (string, int64, error) {
+	manifestPath := filepath.Join(resultDir, "manifest.json")
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return "", 0, err
+	}
+
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:]), int64(len(data)), nil
+}
+
+// PushIndex pushes every per-arch image under its own arch-qualified tag (so one arch's push
+// never overwrites another's), then PUTs indexPath - the application/vnd.oci.image.index.v1+json
+// document written by WriteIndex - as the manifest for ref itself, so ref resolves to a single
+// multi-arch tag.
+func PushIndex(builds []ArchBuild, indexPath, ref string) error {
+	for _, b := range builds {
+		if err := Push(b.ResultDir, archRef(ref, b.OS, b.Architecture)); err != nil {
+			return fmt.Errorf("error: pushing %s/%s manifest: %s", b.OS, b.Architecture, err.Error())
+		}
+	}
+
+	out, err := exec.Command("oras", "manifest", "push", ref, indexPath).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("error: pushing image index: %s: %s", err.Error(), strings.TrimSpace(string(out)))
+	}
+
+	return nil
+}
+
+// archRef qualifies ref with an arch-specific tag so per-arch pushes land under distinct tags
+// of the same repo instead of clobbering each other ahead of the index PUT that makes ref
+// itself resolve to the manifest list
+func archRef(ref, os, arch string) string {
+	repo, tag := ref, "latest"
+	if idx := strings.LastIndex(ref, ":"); idx != -1 && !strings.Contains(ref[idx:], "/") {
+		repo, tag = ref[:idx], ref[idx+1:]
+	}
+	return fmt.Sprintf("%s:%s-%s-%s", repo, tag, os, arch)
+}
@@ -0,0 +1,86 @@
+package oci
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// SignProvider selects how cosign authenticates when signing an image
+type SignProvider string
+
+const (
+	// CosignKeyless signs using cosign's keyless (Fulcio/Rekor) flow
+	CosignKeyless SignProvider = "cosign-keyless"
+	// CosignKeyRef signs using a cosign private key reference (file, KMS URI, ...)
+	CosignKeyRef SignProvider = "cosign-keyref"
+)
+
+// sbomPredicateType is the default in-toto predicate type used for attached SBOMs
+const sbomPredicateType = "https://cyclonedx.org/bom"
+
+// SignImage signs the image manifest at ref with cosign, publishing the signature under the
+// sigstore convention tag sha256-<digest>.sig. provider selects the keyless or keyref flow;
+// keyRef is only required for CosignKeyRef.
+func SignImage(resultDir, ref string, provider SignProvider, keyRef string) error {
+	digest, _, err := manifestDigest(resultDir)
+	if err != nil {
+		return fmt.Errorf("error: computing digest for %s: %s", ref, err.Error())
+	}
+
+	signArgs := []string{"sign", "--yes"}
+	switch provider {
+	case CosignKeyless:
+		// no extra args: cosign defaults to the keyless Fulcio/Rekor flow
+	case CosignKeyRef:
+		if keyRef == "" {
+			return fmt.Errorf("error: --sign cosign-keyref requires a key reference")
+		}
+		signArgs = append(signArgs, "--key", keyRef)
+	default:
+		return fmt.Errorf("error: unsupported sign provider %q", provider)
+	}
+	signArgs = append(signArgs, refAtDigest(ref, digest))
+
+	if out, err := exec.Command("cosign", signArgs...).CombinedOutput(); err != nil {
+		return fmt.Errorf("error: cosign sign failed: %s: %s", err.Error(), strings.TrimSpace(string(out)))
+	}
+
+	return nil
+}
+
+// AttachSBOMAttestation attaches sbomPath to ref as an in-toto attestation under the sigstore
+// convention tag sha256-<digest>.att. predicateType defaults to the CycloneDX predicate when empty.
+func AttachSBOMAttestation(resultDir, ref, sbomPath, predicateType string) error {
+	digest, _, err := manifestDigest(resultDir)
+	if err != nil {
+		return fmt.Errorf("error: computing digest for %s: %s", ref, err.Error())
+	}
+
+	if predicateType == "" {
+		predicateType = sbomPredicateType
+	}
+
+	attestArgs := []string{
+		"attest", "--yes",
+		"--predicate", sbomPath,
+		"--type", predicateType,
+		refAtDigest(ref, digest),
+	}
+
+	if out, err := exec.Command("cosign", attestArgs...).CombinedOutput(); err != nil {
+		return fmt.Errorf("error: cosign attest failed: %s: %s", err.Error(), strings.TrimSpace(string(out)))
+	}
+
+	return nil
+}
+
+// refAtDigest rewrites ref (which may carry a :tag) to the digest form cosign expects, e.g.
+// registry/repo@sha256:...
+func refAtDigest(ref, digest string) string {
+	repo := ref
+	if idx := strings.LastIndex(ref, ":"); idx != -1 && !strings.Contains(ref[idx:], "/") {
+		repo = ref[:idx]
+	}
+	return repo + "@" + digest
+}
@@ -0,0 +1,98 @@
+// Package policy evaluates scanned vulnerabilities against a vulnpolicy block from bsf.hcl,
+// so CI pipelines can gate on severity thresholds and CVE allowlists instead of eyeballing a table.
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	bsfv1 "github.com/buildsafedev/bsf-apis/go/buildsafe/v1"
+)
+
+// Policy is the vulnpolicy block read from bsf.hcl
+type Policy struct {
+	FailOn        string   `hcl:"fail_on,optional"`
+	Allow         []string `hcl:"allow,optional"`
+	MaxCVSS       float64  `hcl:"max_cvss,optional"`
+	IgnoreUnfixed bool     `hcl:"ignore_unfixed,optional"`
+}
+
+// severityRank orders severities from least to most severe so fail_on can be compared by threshold
+var severityRank = map[string]int{
+	"UNKNOWN":  0,
+	"LOW":      1,
+	"MEDIUM":   2,
+	"HIGH":     3,
+	"CRITICAL": 4,
+}
+
+// Violation is a single vulnerability that failed the policy
+type Violation struct {
+	CVE      string  `json:"cve"`
+	Severity string  `json:"severity"`
+	CVSS     float64 `json:"cvss"`
+	Reason   string  `json:"reason"`
+}
+
+// Verdict is the result of evaluating a FetchVulnerabilitiesResponse against a Policy
+type Verdict struct {
+	Compliant  bool        `json:"compliant"`
+	Violations []Violation `json:"violations"`
+}
+
+// Evaluate checks every vulnerability in resp against policy and returns a Verdict. A nil policy
+// is always compliant.
+func Evaluate(policy *Policy, resp *bsfv1.FetchVulnerabilitiesResponse) Verdict {
+	verdict := Verdict{Compliant: true}
+	if policy == nil {
+		return verdict
+	}
+
+	allow := make(map[string]bool, len(policy.Allow))
+	for _, cve := range policy.Allow {
+		allow[cve] = true
+	}
+
+	for _, v := range resp.Vulnerabilities {
+		if allow[v.Id] {
+			continue
+		}
+
+		if policy.IgnoreUnfixed && v.FixedVersion == "" {
+			continue
+		}
+
+		var score float64
+		if len(v.Cvss) > 0 {
+			score = v.Cvss[0].Metrics.BaseScore
+		}
+
+		reason := violationReason(policy, v.Severity, score)
+		if reason == "" {
+			continue
+		}
+
+		verdict.Compliant = false
+		verdict.Violations = append(verdict.Violations, Violation{
+			CVE:      v.Id,
+			Severity: v.Severity,
+			CVSS:     score,
+			Reason:   reason,
+		})
+	}
+
+	return verdict
+}
+
+// violationReason returns why a vulnerability at severity/score fails policy, or "" if it passes
+func violationReason(policy *Policy, severity string, score float64) string {
+	if policy.MaxCVSS > 0 && score > policy.MaxCVSS {
+		return fmt.Sprintf("CVSS score %.1f exceeds max_cvss %.1f", score, policy.MaxCVSS)
+	}
+
+	if policy.FailOn != "" && severityRank[strings.ToUpper(severity)] >= severityRank[strings.ToUpper(policy.FailOn)] {
+		return fmt.Sprintf("severity %s meets fail_on threshold %s", severity, policy.FailOn)
+	}
+
+	return ""
+}
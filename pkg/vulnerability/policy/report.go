@@ -0,0 +1,94 @@
+package policy
+
+import "encoding/json"
+
+// sarifSchema is the SARIF 2.1.0 schema bsf reports conform to, for GitHub code-scanning uploads
+const sarifSchema = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+
+// ToJSON renders verdict as indented JSON
+func ToJSON(verdict Verdict) ([]byte, error) {
+	return json.MarshalIndent(verdict, "", "  ")
+}
+
+// sarifReport mirrors the subset of the SARIF 2.1.0 format bsf needs to report vulnerability
+// policy violations as GitHub code-scanning results
+type sarifReport struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type sarifResult struct {
+	RuleID  string       `json:"ruleId"`
+	Level   string       `json:"level"`
+	Message sarifMessage `json:"message"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+// ToSARIF renders verdict as a SARIF 2.1.0 document, one result per violation, so it can be
+// uploaded to GitHub code-scanning
+func ToSARIF(verdict Verdict) ([]byte, error) {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name: "bsf-scan",
+			},
+		},
+	}
+
+	seen := make(map[string]bool)
+	for _, v := range verdict.Violations {
+		if !seen[v.CVE] {
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: v.CVE, Name: v.CVE})
+			seen[v.CVE] = true
+		}
+
+		run.Results = append(run.Results, sarifResult{
+			RuleID:  v.CVE,
+			Level:   sarifLevel(v.Severity),
+			Message: sarifMessage{Text: v.Reason},
+		})
+	}
+
+	report := sarifReport{
+		Schema:  sarifSchema,
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}
+
+	return json.MarshalIndent(report, "", "  ")
+}
+
+// sarifLevel maps a vulnerability severity to the SARIF result level
+func sarifLevel(severity string) string {
+	switch severity {
+	case "CRITICAL", "HIGH":
+		return "error"
+	case "MEDIUM":
+		return "warning"
+	default:
+		return "note"
+	}
+}